@@ -0,0 +1,112 @@
+package jddf
+
+import "fmt"
+
+// ErrorKind identifies the specific reason a ValidationError was produced,
+// along with the values involved.
+//
+// ErrorKind is a closed set: its only method is unexported, so every
+// implementation lives in this file.
+type ErrorKind interface {
+	isErrorKind()
+}
+
+// TypeMismatch indicates that an instance did not match the type demanded
+// by a FormType schema.
+type TypeMismatch struct {
+	Expected Type
+	Actual   string
+}
+
+func (TypeMismatch) isErrorKind() {}
+
+// EnumMismatch indicates that an instance was not one of the values allowed
+// by a FormEnum schema.
+type EnumMismatch struct {
+	Allowed []string
+	Actual  string
+}
+
+func (EnumMismatch) isErrorKind() {}
+
+// MissingRequiredProperty indicates that an instance was missing a property
+// demanded by a FormProperties schema's "properties" keyword.
+type MissingRequiredProperty struct {
+	Name string
+}
+
+func (MissingRequiredProperty) isErrorKind() {}
+
+// AdditionalProperty indicates that an instance had a property that is
+// neither in "properties" nor "optionalProperties", on a schema that does
+// not set "additionalProperties".
+type AdditionalProperty struct {
+	Name string
+}
+
+func (AdditionalProperty) isErrorKind() {}
+
+// DiscriminatorTagMissing indicates that an instance was missing the
+// property named by a FormDiscriminator schema's "tag".
+type DiscriminatorTagMissing struct{}
+
+func (DiscriminatorTagMissing) isErrorKind() {}
+
+// DiscriminatorTagNotString indicates that an instance's discriminator tag
+// property was present, but was not a string.
+type DiscriminatorTagNotString struct{}
+
+func (DiscriminatorTagNotString) isErrorKind() {}
+
+// DiscriminatorValueUnknown indicates that an instance's discriminator tag
+// was a string, but did not match any key in the schema's "mapping".
+type DiscriminatorValueUnknown struct {
+	Value string
+}
+
+func (DiscriminatorValueUnknown) isErrorKind() {}
+
+// MaxDepthExceeded indicates that validation aborted after following more
+// "ref"s than Validator.MaxDepth allows.
+//
+// Unlike the other kinds, MaxDepthExceeded is never attached to a
+// ValidationError returned in a ValidationResult: exceeding the max depth
+// aborts validation entirely, surfacing as the error returned directly from
+// Validator.Validate. The kind exists so that callers have a way to refer
+// to that condition through the same ErrorKind vocabulary.
+type MaxDepthExceeded struct{}
+
+func (MaxDepthExceeded) isErrorKind() {}
+
+// Locale renders ErrorKinds into human-readable messages.
+type Locale interface {
+	Render(kind ErrorKind) string
+}
+
+type localeEN struct{}
+
+// LocaleEN is a Locale that renders ErrorKinds as English messages.
+var LocaleEN Locale = localeEN{}
+
+func (localeEN) Render(kind ErrorKind) string {
+	switch k := kind.(type) {
+	case TypeMismatch:
+		return fmt.Sprintf("expected a value of type %q, but got %s", k.Expected, k.Actual)
+	case EnumMismatch:
+		return fmt.Sprintf("expected one of %v, but got %s", k.Allowed, k.Actual)
+	case MissingRequiredProperty:
+		return fmt.Sprintf("missing required property %q", k.Name)
+	case AdditionalProperty:
+		return fmt.Sprintf("unexpected additional property %q", k.Name)
+	case DiscriminatorTagMissing:
+		return "missing discriminator tag"
+	case DiscriminatorTagNotString:
+		return "discriminator tag is not a string"
+	case DiscriminatorValueUnknown:
+		return fmt.Sprintf("unknown discriminator value %q", k.Value)
+	case MaxDepthExceeded:
+		return "maximum evaluation depth exceeded"
+	default:
+		return "instance does not satisfy schema"
+	}
+}
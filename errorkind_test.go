@@ -0,0 +1,88 @@
+package jddf_test
+
+import (
+	"testing"
+
+	jddf "github.com/jddf/jddf-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrorKinds(t *testing.T) {
+	type testCase struct {
+		name     string
+		schema   jddf.Schema
+		instance interface{}
+		kind     jddf.ErrorKind
+	}
+
+	testCases := []testCase{
+		{
+			"type mismatch",
+			jddf.Schema{Type: jddf.TypeBoolean},
+			"not a boolean",
+			jddf.TypeMismatch{Expected: jddf.TypeBoolean, Actual: "string"},
+		},
+		{
+			"enum mismatch",
+			jddf.Schema{Enum: []string{"a", "b"}},
+			"c",
+			jddf.EnumMismatch{Allowed: []string{"a", "b"}, Actual: "c"},
+		},
+		{
+			"missing required property",
+			jddf.Schema{RequiredProperties: map[string]jddf.Schema{"a": {}}},
+			map[string]interface{}{},
+			jddf.MissingRequiredProperty{Name: "a"},
+		},
+		{
+			"additional property",
+			jddf.Schema{RequiredProperties: map[string]jddf.Schema{}},
+			map[string]interface{}{"a": 1},
+			jddf.AdditionalProperty{Name: "a"},
+		},
+		{
+			"discriminator tag missing",
+			jddf.Schema{Discriminator: jddf.Discriminator{
+				Tag:     "type",
+				Mapping: map[string]jddf.Schema{"a": {RequiredProperties: map[string]jddf.Schema{}}},
+			}},
+			map[string]interface{}{},
+			jddf.DiscriminatorTagMissing{},
+		},
+		{
+			"discriminator tag not string",
+			jddf.Schema{Discriminator: jddf.Discriminator{
+				Tag:     "type",
+				Mapping: map[string]jddf.Schema{"a": {RequiredProperties: map[string]jddf.Schema{}}},
+			}},
+			map[string]interface{}{"type": 1},
+			jddf.DiscriminatorTagNotString{},
+		},
+		{
+			"discriminator value unknown",
+			jddf.Schema{Discriminator: jddf.Discriminator{
+				Tag:     "type",
+				Mapping: map[string]jddf.Schema{"a": {RequiredProperties: map[string]jddf.Schema{}}},
+			}},
+			map[string]interface{}{"type": "b"},
+			jddf.DiscriminatorValueUnknown{Value: "b"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := jddf.Validator{}
+
+			result, err := validator.Validate(tt.schema, tt.instance)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(result.Errors))
+			assert.Equal(t, tt.kind, result.Errors[0].Kind)
+			assert.NotEmpty(t, result.Errors[0].Error())
+		})
+	}
+}
+
+func TestValidationErrorLocale(t *testing.T) {
+	err := jddf.ValidationError{Kind: jddf.MissingRequiredProperty{Name: "foo"}}
+	assert.Equal(t, jddf.LocaleEN.Render(err.Kind), err.Error())
+}
@@ -35,7 +35,41 @@ var ErrMaxDepthExceeded = errors.New("jddf: maximum evaluation depth exceeded")
 type ErrNoSuchDefinition string
 
 func (e ErrNoSuchDefinition) Error() string {
-	return fmt.Sprintf("jddf: no such definition: %s", e)
+	return fmt.Sprintf("jddf: no such definition: %s", string(e))
+}
+
+// ErrDuplicateSchemaID indicates that a Loader already had a schema
+// registered under a given ID.
+type ErrDuplicateSchemaID string
+
+func (e ErrDuplicateSchemaID) Error() string {
+	return fmt.Sprintf("jddf: duplicate schema id: %s", string(e))
+}
+
+// ErrNoSuchSchemaID indicates that a ref of the form "id#name", or a call to
+// Validator.ValidateCompiled, named a schema ID that a Loader never had a
+// schema registered for.
+type ErrNoSuchSchemaID string
+
+func (e ErrNoSuchSchemaID) Error() string {
+	return fmt.Sprintf("jddf: no such schema id: %s", string(e))
+}
+
+// ErrCyclicRef indicates that Flatten or FlattenDepth encountered a ref that
+// refers back to itself through a chain of other refs. Such a schema cannot
+// be flattened, since doing so would require producing an infinite schema.
+type ErrCyclicRef string
+
+func (e ErrCyclicRef) Error() string {
+	return fmt.Sprintf("jddf: cyclic ref: %s", string(e))
+}
+
+// ErrUnknownFormat indicates that a schema's "format" keyword named a format
+// that is not registered with the Validator's FormatRegistry.
+type ErrUnknownFormat string
+
+func (e ErrUnknownFormat) Error() string {
+	return fmt.Sprintf("jddf: unknown format: %s", string(e))
 }
 
 // ErrInvalidType indicates that a "type" had an incorrect value.
@@ -44,7 +78,7 @@ func (e ErrNoSuchDefinition) Error() string {
 type ErrInvalidType string
 
 func (e ErrInvalidType) Error() string {
-	return fmt.Sprintf("jddf: no such type: %s", e)
+	return fmt.Sprintf("jddf: no such type: %s", string(e))
 }
 
 // ErrRepeatedEnumValue indicates than an "enum" repeated a value. Enums must
@@ -52,7 +86,7 @@ func (e ErrInvalidType) Error() string {
 type ErrRepeatedEnumValue string
 
 func (e ErrRepeatedEnumValue) Error() string {
-	return fmt.Sprintf("jddf: repeated enum value: %s", e)
+	return fmt.Sprintf("jddf: repeated enum value: %s", string(e))
 }
 
 // ErrRepeatedProperty indicates that a schema had a "properties" and
@@ -60,7 +94,7 @@ func (e ErrRepeatedEnumValue) Error() string {
 type ErrRepeatedProperty string
 
 func (e ErrRepeatedProperty) Error() string {
-	return fmt.Sprintf("jddf: repeated property in properties and optionalProperties: %s", e)
+	return fmt.Sprintf("jddf: repeated property in properties and optionalProperties: %s", string(e))
 }
 
 // ErrRepeatedTagInProperties indicates that one of the elements of
@@ -69,5 +103,5 @@ func (e ErrRepeatedProperty) Error() string {
 type ErrRepeatedTagInProperties string
 
 func (e ErrRepeatedTagInProperties) Error() string {
-	return fmt.Sprintf("jddf: discriminator tag repeated in properties or optionalProperties: %s", e)
+	return fmt.Sprintf("jddf: discriminator tag repeated in properties or optionalProperties: %s", string(e))
 }
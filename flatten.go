@@ -0,0 +1,132 @@
+package jddf
+
+// Flatten produces an equivalent schema with every Ref replaced by a copy of
+// the definition it points to. Subschemas are copied deeply; Enum slices and
+// Metadata maps are shared with s, not copied. The result has no Definitions and no
+// Ref nodes, which is useful for code generation, for shipping schemas to
+// consumers that lack a ref resolver, and for producing a canonical form to
+// diff against.
+//
+// Flatten returns ErrCyclicRef if s contains a ref cycle, since expanding
+// one would require producing an infinite schema.
+func Flatten(s Schema) (Schema, error) {
+	return FlattenDepth(s, 0)
+}
+
+// FlattenDepth behaves like Flatten, but also aborts with
+// ErrMaxDepthExceeded once more than max refs have been expanded along any
+// single path. A max of zero imposes no such bound, though ref cycles are
+// still rejected.
+func FlattenDepth(s Schema, max int) (Schema, error) {
+	f := flattener{defs: s.Definitions, max: max}
+	return f.flatten(s, nil, 0)
+}
+
+type flattener struct {
+	defs map[string]Schema
+	max  int
+}
+
+func (f flattener) flatten(s Schema, expanding map[string]bool, depth int) (Schema, error) {
+	if s.Ref != nil {
+		if f.max > 0 && depth >= f.max {
+			return Schema{}, ErrMaxDepthExceeded
+		}
+
+		if expanding[*s.Ref] {
+			return Schema{}, ErrCyclicRef(*s.Ref)
+		}
+
+		expanding = withRef(expanding, *s.Ref)
+		referent, err := f.flatten(f.defs[*s.Ref], expanding, depth+1)
+		if err != nil {
+			return Schema{}, err
+		}
+
+		// A nullable ref accepts null whatever its referent says, so that
+		// carries over onto the referent which replaces it.
+		if s.Nullable {
+			referent.Nullable = true
+		}
+
+		return referent, nil
+	}
+
+	out := s
+	out.Definitions = nil
+	out.Ref = nil
+
+	if s.Elements != nil {
+		elements, err := f.flatten(*s.Elements, expanding, depth)
+		if err != nil {
+			return Schema{}, err
+		}
+
+		out.Elements = &elements
+	}
+
+	if s.RequiredProperties != nil {
+		flat, err := f.flattenSchemaMap(s.RequiredProperties, expanding, depth)
+		if err != nil {
+			return Schema{}, err
+		}
+
+		out.RequiredProperties = flat
+	}
+
+	if s.OptionalProperties != nil {
+		flat, err := f.flattenSchemaMap(s.OptionalProperties, expanding, depth)
+		if err != nil {
+			return Schema{}, err
+		}
+
+		out.OptionalProperties = flat
+	}
+
+	if s.Values != nil {
+		values, err := f.flatten(*s.Values, expanding, depth)
+		if err != nil {
+			return Schema{}, err
+		}
+
+		out.Values = &values
+	}
+
+	if s.Discriminator.Mapping != nil {
+		mapping, err := f.flattenSchemaMap(s.Discriminator.Mapping, expanding, depth)
+		if err != nil {
+			return Schema{}, err
+		}
+
+		out.Discriminator = Discriminator{Tag: s.Discriminator.Tag, Mapping: mapping}
+	}
+
+	return out, nil
+}
+
+func (f flattener) flattenSchemaMap(in map[string]Schema, expanding map[string]bool, depth int) (map[string]Schema, error) {
+	out := make(map[string]Schema, len(in))
+
+	for name, sub := range in {
+		flat, err := f.flatten(sub, expanding, depth)
+		if err != nil {
+			return nil, err
+		}
+
+		out[name] = flat
+	}
+
+	return out, nil
+}
+
+// withRef returns a copy of expanding with ref added, leaving expanding
+// itself untouched so that sibling subtrees don't see each other's refs.
+func withRef(expanding map[string]bool, ref string) map[string]bool {
+	next := make(map[string]bool, len(expanding)+1)
+	for k := range expanding {
+		next[k] = true
+	}
+
+	next[ref] = true
+	return next
+}
@@ -0,0 +1,108 @@
+package jddf_test
+
+import (
+	"testing"
+
+	jddf "github.com/jddf/jddf-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	type testCase struct {
+		name string
+		in   jddf.Schema
+		out  jddf.Schema
+		err  error
+	}
+
+	testCases := []testCase{
+		{
+			"no refs",
+			jddf.Schema{Type: jddf.TypeBoolean},
+			jddf.Schema{Type: jddf.TypeBoolean},
+			nil,
+		},
+		{
+			"root is a ref",
+			jddf.Schema{
+				Definitions: map[string]jddf.Schema{"a": {Type: jddf.TypeBoolean}},
+				Ref:         strptr("a"),
+			},
+			jddf.Schema{Type: jddf.TypeBoolean},
+			nil,
+		},
+		{
+			"ref nested in properties",
+			jddf.Schema{
+				Definitions: map[string]jddf.Schema{"a": {Type: jddf.TypeBoolean}},
+				RequiredProperties: map[string]jddf.Schema{
+					"x": {Ref: strptr("a")},
+				},
+			},
+			jddf.Schema{
+				RequiredProperties: map[string]jddf.Schema{
+					"x": {Type: jddf.TypeBoolean},
+				},
+			},
+			nil,
+		},
+		{
+			"ref nested in discriminator mapping",
+			jddf.Schema{
+				Definitions: map[string]jddf.Schema{
+					"a": {RequiredProperties: map[string]jddf.Schema{"b": {}}},
+				},
+				Discriminator: jddf.Discriminator{
+					Tag:     "type",
+					Mapping: map[string]jddf.Schema{"a": {Ref: strptr("a")}},
+				},
+			},
+			jddf.Schema{
+				Discriminator: jddf.Discriminator{
+					Tag:     "type",
+					Mapping: map[string]jddf.Schema{"a": {RequiredProperties: map[string]jddf.Schema{"b": {}}}},
+				},
+			},
+			nil,
+		},
+		{
+			"cyclic ref",
+			jddf.Schema{
+				Definitions: map[string]jddf.Schema{"a": {Ref: strptr("a")}},
+				Ref:         strptr("a"),
+			},
+			jddf.Schema{},
+			jddf.ErrCyclicRef("a"),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := jddf.Flatten(tt.in)
+			assert.Equal(t, tt.err, err)
+
+			if tt.err == nil {
+				assert.Equal(t, tt.out, out)
+				assert.Nil(t, out.Definitions)
+				assert.Nil(t, out.Ref)
+			}
+		})
+	}
+}
+
+func TestFlattenDepth(t *testing.T) {
+	schema := jddf.Schema{
+		Definitions: map[string]jddf.Schema{
+			"a": {Ref: strptr("b")},
+			"b": {Type: jddf.TypeBoolean},
+		},
+		Ref: strptr("a"),
+	}
+
+	_, err := jddf.FlattenDepth(schema, 1)
+	assert.Equal(t, jddf.ErrMaxDepthExceeded, err)
+
+	out, err := jddf.FlattenDepth(schema, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, jddf.Schema{Type: jddf.TypeBoolean}, out)
+}
@@ -0,0 +1,164 @@
+package jddf
+
+import (
+	"net"
+	"net/mail"
+	"regexp"
+	"time"
+)
+
+// FormatChecker determines whether a string instance satisfies a named,
+// non-standard "format" keyword.
+//
+// FormatChecker exists to let users enforce stricter string validation than
+// the eight JDDF forms provide for on their own, in a way that does not
+// affect Schema.Form or the core spec's notion of schema correctness.
+type FormatChecker interface {
+	IsFormat(input interface{}) bool
+}
+
+// formatCheckerFunc adapts a function into a FormatChecker.
+type formatCheckerFunc func(input interface{}) bool
+
+func (f formatCheckerFunc) IsFormat(input interface{}) bool {
+	return f(input)
+}
+
+// FormatRegistry is a collection of named FormatCheckers, consulted by a
+// Validator whenever it encounters a "format" keyword on a FormType schema
+// with Type == TypeString.
+type FormatRegistry struct {
+	checkers map[string]FormatChecker
+}
+
+// NewFormatRegistry returns an empty FormatRegistry.
+func NewFormatRegistry() *FormatRegistry {
+	return &FormatRegistry{checkers: map[string]FormatChecker{}}
+}
+
+// Register associates name with checker. A subsequent call with the same
+// name replaces the previous registration.
+func (r *FormatRegistry) Register(name string, checker FormatChecker) {
+	r.checkers[name] = checker
+}
+
+// Get returns the FormatChecker registered under name, if any.
+func (r *FormatRegistry) Get(name string) (FormatChecker, bool) {
+	checker, ok := r.checkers[name]
+	return checker, ok
+}
+
+// DefaultFormatRegistry is a FormatRegistry pre-populated with checkers for
+// "date-time", "date", "time", "uuid", "email", "ipv4", "ipv6", "hostname",
+// and "duration". Assign it to Validator.Formats to enable these checks, or
+// build on top of it with additional calls to Register.
+var DefaultFormatRegistry = NewFormatRegistry()
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+func init() {
+	DefaultFormatRegistry.Register("date-time", formatCheckerFunc(isDateTime))
+	DefaultFormatRegistry.Register("date", formatCheckerFunc(isDate))
+	DefaultFormatRegistry.Register("time", formatCheckerFunc(isTime))
+	DefaultFormatRegistry.Register("uuid", formatCheckerFunc(isUUID))
+	DefaultFormatRegistry.Register("email", formatCheckerFunc(isEmail))
+	DefaultFormatRegistry.Register("ipv4", formatCheckerFunc(isIPv4))
+	DefaultFormatRegistry.Register("ipv6", formatCheckerFunc(isIPv6))
+	DefaultFormatRegistry.Register("hostname", formatCheckerFunc(isHostname))
+	DefaultFormatRegistry.Register("duration", formatCheckerFunc(isDuration))
+}
+
+func asString(input interface{}) (string, bool) {
+	s, ok := input.(string)
+	return s, ok
+}
+
+func isDateTime(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
+
+func isDate(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+func isTime(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	_, err := time.Parse("15:04:05", s)
+	return err == nil
+}
+
+func isUUID(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	return uuidPattern.MatchString(s)
+}
+
+func isEmail(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	_, err := mail.ParseAddress(s)
+	return err == nil
+}
+
+func isIPv4(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil
+}
+
+func isIPv6(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() == nil
+}
+
+func isHostname(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	return len(s) <= 253 && hostnamePattern.MatchString(s)
+}
+
+func isDuration(input interface{}) bool {
+	s, ok := asString(input)
+	if !ok {
+		return false
+	}
+
+	_, err := time.ParseDuration(s)
+	return err == nil
+}
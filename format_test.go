@@ -0,0 +1,71 @@
+package jddf_test
+
+import (
+	"testing"
+
+	jddf "github.com/jddf/jddf-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultFormatRegistry(t *testing.T) {
+	testCases := []struct {
+		format string
+		good   string
+		bad    string
+	}{
+		{"date-time", "2021-01-01T12:00:00Z", "not-a-date-time"},
+		{"date", "2021-01-01", "2021-13-01"},
+		{"time", "12:00:00", "25:00:00"},
+		{"uuid", "123e4567-e89b-12d3-a456-426614174000", "not-a-uuid"},
+		{"email", "user@example.com", "not-an-email"},
+		{"ipv4", "127.0.0.1", "::1"},
+		{"ipv6", "::1", "127.0.0.1"},
+		{"hostname", "example.com", "-not-valid-"},
+		{"duration", "1h30m", "not-a-duration"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.format, func(t *testing.T) {
+			checker, ok := jddf.DefaultFormatRegistry.Get(tt.format)
+			assert.True(t, ok)
+
+			assert.True(t, checker.IsFormat(tt.good))
+			assert.False(t, checker.IsFormat(tt.bad))
+		})
+	}
+}
+
+func TestValidatorFormats(t *testing.T) {
+	schema := jddf.Schema{Type: jddf.TypeString, Format: "email"}
+
+	t.Run("nil registry", func(t *testing.T) {
+		validator := jddf.Validator{}
+
+		result, err := validator.Validate(schema, "not-an-email")
+		assert.NoError(t, err)
+		assert.Empty(t, result.Errors)
+	})
+
+	t.Run("unknown format name", func(t *testing.T) {
+		validator := jddf.Validator{Formats: jddf.DefaultFormatRegistry}
+
+		_, err := validator.Validate(jddf.Schema{Type: jddf.TypeString, Format: "nonsense"}, "anything")
+		assert.Equal(t, jddf.ErrUnknownFormat("nonsense"), err)
+	})
+
+	t.Run("format mismatch", func(t *testing.T) {
+		validator := jddf.Validator{Formats: jddf.DefaultFormatRegistry}
+
+		result, err := validator.Validate(schema, "not-an-email")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(result.Errors))
+	})
+
+	t.Run("format match", func(t *testing.T) {
+		validator := jddf.Validator{Formats: jddf.DefaultFormatRegistry}
+
+		result, err := validator.Validate(schema, "user@example.com")
+		assert.NoError(t, err)
+		assert.Empty(t, result.Errors)
+	})
+}
@@ -0,0 +1,100 @@
+package jddf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Loader accumulates Schemas under stable IDs, so that refs of the form
+// "id#name" can be resolved across documents by Compile.
+//
+// A Loader's zero value is ready to use.
+type Loader struct {
+	schemas map[string]Schema
+}
+
+// AddSchema registers s under id. It is an error to register two schemas
+// under the same id.
+func (l *Loader) AddSchema(id string, s Schema) error {
+	if l.schemas == nil {
+		l.schemas = map[string]Schema{}
+	}
+
+	if _, ok := l.schemas[id]; ok {
+		return ErrDuplicateSchemaID(id)
+	}
+
+	l.schemas[id] = s
+	return nil
+}
+
+// AddFromReader reads a single schema as JSON from r, and registers it under
+// id via AddSchema.
+func (l *Loader) AddFromReader(id string, r io.Reader) error {
+	var s Schema
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return err
+	}
+
+	return l.AddSchema(id, s)
+}
+
+// AddFromFile reads a single schema as JSON from the file at path, and
+// registers it via AddSchema. The schema's ID field is used as its id if
+// set; otherwise path is used.
+func (l *Loader) AddFromFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var s Schema
+	if err := json.NewDecoder(file).Decode(&s); err != nil {
+		return err
+	}
+
+	id := s.ID
+	if id == "" {
+		id = path
+	}
+
+	return l.AddSchema(id, s)
+}
+
+// CompiledSchema is a frozen, verified bundle of schemas produced by
+// Loader.Compile. Use Validator.ValidateCompiled to validate instances
+// against one of its schemas.
+type CompiledSchema struct {
+	schemas map[string]Schema
+}
+
+// Compile verifies every schema registered with l, and returns a
+// CompiledSchema bundling all of them together for cross-document ref
+// resolution.
+//
+// Each schema's local refs continue to be resolved against its own
+// Definitions, exactly as with a plain Schema.Verify. A ref of the form
+// "id#name" is additionally resolved against the Definitions of the schema
+// registered under "id"; a ref of the form "id#" (an empty name) resolves to
+// the root of the schema registered under "id".
+//
+// The whole pool is assembled before any schema is verified, so that a
+// cross-document ref can be resolved against a schema registered later than
+// the one referring to it.
+func (l *Loader) Compile() (*CompiledSchema, error) {
+	schemas := make(map[string]Schema, len(l.schemas))
+	for id, s := range l.schemas {
+		schemas[id] = s
+	}
+
+	for id, s := range schemas {
+		if err := s.verifyPool(id, schemas); err != nil {
+			return nil, fmt.Errorf("jddf: schema %q: %w", id, err)
+		}
+	}
+
+	return &CompiledSchema{schemas: schemas}, nil
+}
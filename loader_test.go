@@ -0,0 +1,122 @@
+package jddf_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	jddf "github.com/jddf/jddf-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoaderDuplicateID(t *testing.T) {
+	loader := jddf.Loader{}
+
+	assert.NoError(t, loader.AddSchema("a", jddf.Schema{}))
+	assert.Equal(t, jddf.ErrDuplicateSchemaID("a"), loader.AddSchema("a", jddf.Schema{}))
+}
+
+func TestLoaderAddFromReader(t *testing.T) {
+	loader := jddf.Loader{}
+
+	assert.NoError(t, loader.AddFromReader("a", strings.NewReader(`{"type":"boolean"}`)))
+
+	compiled, err := loader.Compile()
+	assert.NoError(t, err)
+
+	validator := jddf.Validator{}
+	result, err := validator.ValidateCompiled(compiled, "a", true)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Errors)
+}
+
+func TestLoaderAddFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	withoutID := filepath.Join(dir, "schema.json")
+	assert.NoError(t, os.WriteFile(withoutID, []byte(`{"type":"boolean"}`), 0644))
+
+	loader := jddf.Loader{}
+	assert.NoError(t, loader.AddFromFile(withoutID))
+
+	compiled, err := loader.Compile()
+	assert.NoError(t, err)
+
+	validator := jddf.Validator{}
+	result, err := validator.ValidateCompiled(compiled, withoutID, true)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Errors)
+
+	withID := filepath.Join(dir, "other.json")
+	assert.NoError(t, os.WriteFile(withID, []byte(`{"id":"named","type":"string"}`), 0644))
+	assert.NoError(t, loader.AddFromFile(withID))
+
+	compiled, err = loader.Compile()
+	assert.NoError(t, err)
+
+	result, err = validator.ValidateCompiled(compiled, "named", "hello")
+	assert.NoError(t, err)
+	assert.Empty(t, result.Errors)
+}
+
+func TestCompileRejectsInvalidSchema(t *testing.T) {
+	loader := jddf.Loader{}
+	assert.NoError(t, loader.AddSchema("a", jddf.Schema{Ref: strptr("missing")}))
+
+	_, err := loader.Compile()
+	assert.Error(t, err)
+}
+
+func TestValidateCompiledCrossDocumentRef(t *testing.T) {
+	loader := jddf.Loader{}
+
+	assert.NoError(t, loader.AddSchema("common", jddf.Schema{
+		Definitions: map[string]jddf.Schema{
+			"positiveInt": {Type: jddf.TypeUint32},
+		},
+	}))
+
+	assert.NoError(t, loader.AddSchema("main", jddf.Schema{
+		RequiredProperties: map[string]jddf.Schema{
+			"count": {Ref: strptr("common#positiveInt")},
+		},
+	}))
+
+	compiled, err := loader.Compile()
+	assert.NoError(t, err)
+
+	validator := jddf.Validator{}
+
+	result, err := validator.ValidateCompiled(compiled, "main", map[string]interface{}{"count": float64(3)})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Errors)
+
+	result, err = validator.ValidateCompiled(compiled, "main", map[string]interface{}{"count": "not a number"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Errors))
+}
+
+func TestValidateCompiledUnknownID(t *testing.T) {
+	loader := jddf.Loader{}
+	compiled, err := loader.Compile()
+	assert.NoError(t, err)
+
+	validator := jddf.Validator{}
+	_, err = validator.ValidateCompiled(compiled, "nonexistent", nil)
+	assert.Equal(t, jddf.ErrNoSuchSchemaID("nonexistent"), err)
+}
+
+func TestValidateCompiledCrossDocumentCycle(t *testing.T) {
+	loader := jddf.Loader{}
+
+	assert.NoError(t, loader.AddSchema("a", jddf.Schema{Ref: strptr("b#")}))
+	assert.NoError(t, loader.AddSchema("b", jddf.Schema{Ref: strptr("a#")}))
+
+	compiled, err := loader.Compile()
+	assert.NoError(t, err)
+
+	validator := jddf.Validator{}
+	_, err = validator.ValidateCompiled(compiled, "a", nil)
+	assert.Equal(t, jddf.ErrMaxDepthExceeded, err)
+}
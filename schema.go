@@ -0,0 +1,243 @@
+package jddf
+
+// Type is an enumeration of the values that a Schema's "type" keyword may
+// take on.
+type Type string
+
+const (
+	TypeBoolean   Type = "boolean"
+	TypeString    Type = "string"
+	TypeTimestamp Type = "timestamp"
+	TypeFloat32   Type = "float32"
+	TypeFloat64   Type = "float64"
+	TypeInt8      Type = "int8"
+	TypeUint8     Type = "uint8"
+	TypeInt16     Type = "int16"
+	TypeUint16    Type = "uint16"
+	TypeInt32     Type = "int32"
+	TypeUint32    Type = "uint32"
+)
+
+// Form is an enumeration of the eight forms a Schema may take on, as defined
+// by the JDDF specification.
+type Form int
+
+const (
+	FormEmpty Form = iota
+	FormRef
+	FormType
+	FormEnum
+	FormElements
+	FormProperties
+	FormValues
+	FormDiscriminator
+)
+
+// Discriminator represents the "discriminator" keyword of a Schema.
+type Discriminator struct {
+	Tag     string            `json:"tag"`
+	Mapping map[string]Schema `json:"mapping"`
+}
+
+// Schema represents a JDDF schema, in its raw, unverified form.
+//
+// A Schema is only meaningful once it has been checked with Verify. Passing
+// an un-verified Schema to a Validator produces undefined behavior.
+type Schema struct {
+	// ID optionally names this schema for the purposes of cross-document ref
+	// resolution through a Loader. It plays no role in Schema.Form or
+	// Schema.Verify.
+	ID                   string                 `json:"id,omitempty"`
+	Definitions          map[string]Schema      `json:"definitions,omitempty"`
+	Ref                  *string                `json:"ref,omitempty"`
+	Type                 Type                   `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Elements             *Schema                `json:"elements,omitempty"`
+	RequiredProperties   map[string]Schema      `json:"properties,omitempty"`
+	OptionalProperties   map[string]Schema      `json:"optionalProperties,omitempty"`
+	AdditionalProperties bool                   `json:"additionalProperties,omitempty"`
+	Values               *Schema                `json:"values,omitempty"`
+	Discriminator        Discriminator          `json:"discriminator,omitempty"`
+	Nullable             bool                   `json:"nullable,omitempty"`
+	Metadata             map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Form returns the form that s takes on, per the JDDF specification's form-
+// discriminator algorithm.
+//
+// Form does not take into account whether s is a well-formed schema of that
+// form; use Verify for that.
+func (s Schema) Form() Form {
+	switch {
+	case s.Ref != nil:
+		return FormRef
+	case s.Type != "":
+		return FormType
+	case s.Enum != nil:
+		return FormEnum
+	case s.Elements != nil:
+		return FormElements
+	case s.RequiredProperties != nil || s.OptionalProperties != nil:
+		return FormProperties
+	case s.Values != nil:
+		return FormValues
+	case s.Discriminator.Mapping != nil:
+		return FormDiscriminator
+	default:
+		return FormEmpty
+	}
+}
+
+// Verify checks that s, and all of its subschemas, are well-formed instances
+// of one of the eight forms described by the JDDF specification.
+//
+// Verify does not guarantee that s is free of ref cycles that would cause a
+// Validator to recurse indefinitely; that is instead guarded against at
+// validation time, using Validator.MaxDepth.
+func (s Schema) Verify() error {
+	return s.verify(s.Definitions, true, "", nil)
+}
+
+// verifyPool checks that s, and all of its subschemas, are well-formed, the
+// same way Verify does, except that a ref of the form "id#name" is resolved
+// against pool (as assembled by Loader.Compile) instead of being treated as
+// unresolvable. docID is the id s itself is registered under, and gives the
+// "#name" shorthand for self-refs its meaning.
+//
+// It is used by Loader.Compile so that cross-document refs verify
+// successfully; plain Schema.Verify has no pool and continues to reject them
+// exactly as before.
+func (s Schema) verifyPool(docID string, pool map[string]Schema) error {
+	return s.verify(s.Definitions, true, docID, pool)
+}
+
+func (s Schema) verify(rootDefs map[string]Schema, isRoot bool, docID string, pool map[string]Schema) error {
+	if !isRoot && s.Definitions != nil {
+		return ErrNonRootDefinition
+	}
+
+	switch s.Form() {
+	case FormRef:
+		if s.Type != "" || s.Enum != nil || s.Elements != nil || s.RequiredProperties != nil ||
+			s.OptionalProperties != nil || s.Values != nil || s.Discriminator.Mapping != nil {
+			return ErrInvalidForm
+		}
+
+		if pool != nil {
+			refDocID, name, hadHash := splitRef(*s.Ref, docID)
+			if hadHash {
+				doc, ok := pool[refDocID]
+				if !ok {
+					return ErrNoSuchSchemaID(refDocID)
+				}
+
+				if name != "" {
+					if _, ok := doc.Definitions[name]; !ok {
+						return ErrNoSuchDefinition(*s.Ref)
+					}
+				}
+
+				return nil
+			}
+		}
+
+		if _, ok := rootDefs[*s.Ref]; !ok {
+			return ErrNoSuchDefinition(*s.Ref)
+		}
+
+		return nil
+	case FormType:
+		if s.Enum != nil || s.Elements != nil || s.RequiredProperties != nil ||
+			s.OptionalProperties != nil || s.Values != nil || s.Discriminator.Mapping != nil {
+			return ErrInvalidForm
+		}
+
+		switch s.Type {
+		case TypeBoolean, TypeString, TypeTimestamp, TypeFloat32, TypeFloat64,
+			TypeInt8, TypeUint8, TypeInt16, TypeUint16, TypeInt32, TypeUint32:
+			return nil
+		default:
+			return ErrInvalidType(s.Type)
+		}
+	case FormEnum:
+		if s.Elements != nil || s.RequiredProperties != nil || s.OptionalProperties != nil ||
+			s.Values != nil || s.Discriminator.Mapping != nil {
+			return ErrInvalidForm
+		}
+
+		if len(s.Enum) == 0 {
+			return ErrEmptyEnum
+		}
+
+		seen := map[string]bool{}
+		for _, value := range s.Enum {
+			if seen[value] {
+				return ErrRepeatedEnumValue(value)
+			}
+
+			seen[value] = true
+		}
+
+		return nil
+	case FormElements:
+		if s.RequiredProperties != nil || s.OptionalProperties != nil ||
+			s.Values != nil || s.Discriminator.Mapping != nil {
+			return ErrInvalidForm
+		}
+
+		return s.Elements.verify(rootDefs, false, docID, pool)
+	case FormProperties:
+		if s.Values != nil || s.Discriminator.Mapping != nil {
+			return ErrInvalidForm
+		}
+
+		for name := range s.RequiredProperties {
+			if _, ok := s.OptionalProperties[name]; ok {
+				return ErrRepeatedProperty(name)
+			}
+		}
+
+		for _, sub := range s.RequiredProperties {
+			if err := sub.verify(rootDefs, false, docID, pool); err != nil {
+				return err
+			}
+		}
+
+		for _, sub := range s.OptionalProperties {
+			if err := sub.verify(rootDefs, false, docID, pool); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case FormValues:
+		if s.Discriminator.Mapping != nil {
+			return ErrInvalidForm
+		}
+
+		return s.Values.verify(rootDefs, false, docID, pool)
+	case FormDiscriminator:
+		for _, sub := range s.Discriminator.Mapping {
+			if sub.Form() != FormProperties {
+				return ErrNonPropertiesMapping
+			}
+
+			if _, ok := sub.RequiredProperties[s.Discriminator.Tag]; ok {
+				return ErrRepeatedTagInProperties(s.Discriminator.Tag)
+			}
+
+			if _, ok := sub.OptionalProperties[s.Discriminator.Tag]; ok {
+				return ErrRepeatedTagInProperties(s.Discriminator.Tag)
+			}
+
+			if err := sub.verify(rootDefs, false, docID, pool); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,404 @@
+package jddf
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// ValidateStream validates the JSON instance read from r against schema,
+// invoking cb with each ValidationError as it is found. Returning false
+// from cb stops validation early, the same way Validator.MaxErrors does for
+// Validate.
+//
+// Unlike Validate, ValidateStream consumes r through encoding/json's token
+// stream instead of first unmarshaling the whole instance into memory. It
+// descends in lockstep with schema's form: FormElements and
+// FormValues/FormProperties iterate the underlying array or object tokens one
+// at a time, while the leaf forms (FormEmpty, FormType, FormEnum, FormRef)
+// decode a single value and delegate to the same logic Validate uses. A value
+// under a leaf form is therefore held in memory whole, however large it is,
+// and so are the properties of a FormDiscriminator object that precede its
+// tag.
+func (v Validator) ValidateStream(schema Schema, r io.Reader, cb func(ValidationError) bool) error {
+	locale := v.Locale
+	if locale == nil {
+		locale = LocaleEN
+	}
+
+	state := &validationState{
+		root:     schema,
+		maxDepth: v.MaxDepth,
+		formats:  v.Formats,
+		locale:   locale,
+		sink:     cb,
+	}
+
+	decoder := json.NewDecoder(r)
+
+	err := state.validateStreamValue(decoder, schema, []string{}, []string{}, 0)
+	if err == errStreamStopped {
+		return nil
+	}
+
+	return err
+}
+
+func (st *validationState) validateStreamValue(decoder *json.Decoder, schema Schema, instancePath, schemaPath []string, depth int) error {
+	switch schema.Form() {
+	case FormElements:
+		return st.validateElementsStream(decoder, schema, instancePath, schemaPath, depth)
+	case FormProperties:
+		return st.validatePropertiesStream(decoder, schema, instancePath, schemaPath, depth)
+	case FormValues:
+		return st.validateValuesStream(decoder, schema, instancePath, schemaPath, depth)
+	case FormDiscriminator:
+		return st.validateDiscriminatorStream(decoder, schema, instancePath, schemaPath, depth)
+	default:
+		// FormEmpty, FormType, FormEnum, FormRef: each is fully determined
+		// by a single JSON value, so there's nothing to gain from streaming
+		// it. For FormRef, validate also applies the ref's own Nullable and
+		// MaxDepth before following the ref, exactly as for Validate.
+		var instance interface{}
+		if err := decoder.Decode(&instance); err != nil {
+			return err
+		}
+
+		return st.validate(schema, instance, instancePath, schemaPath, depth)
+	}
+}
+
+func (st *validationState) validateElementsStream(decoder *json.Decoder, schema Schema, instancePath, schemaPath []string, depth int) error {
+	tok, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		if schema.Nullable {
+			return nil
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		if err := skipValue(decoder, tok); err != nil {
+			return err
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	elementsSchemaPath := append(append([]string{}, schemaPath...), "elements")
+
+	for i := 0; decoder.More(); i++ {
+		elemInstancePath := append(append([]string{}, instancePath...), strconv.Itoa(i))
+		if err := st.validateStreamValue(decoder, *schema.Elements, elemInstancePath, elementsSchemaPath, depth); err != nil {
+			return err
+		}
+	}
+
+	_, err = decoder.Token() // closing ']'
+	return err
+}
+
+func (st *validationState) validatePropertiesStream(decoder *json.Decoder, schema Schema, instancePath, schemaPath []string, depth int) error {
+	tok, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		if schema.Nullable {
+			return nil
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		if err := skipValue(decoder, tok); err != nil {
+			return err
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	seen := map[string]bool{}
+
+	for decoder.More() {
+		keyTok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		name, _ := keyTok.(string)
+		seen[name] = true
+
+		if err := st.validateMappedProperty(decoder, schema, name, instancePath, schemaPath, depth); err != nil {
+			return err
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil { // closing '}'
+		return err
+	}
+
+	for name := range schema.RequiredProperties {
+		if seen[name] {
+			continue
+		}
+
+		subSchemaPath := append(append([]string{}, schemaPath...), "properties", name)
+		if err := st.addErrorKind(instancePath, subSchemaPath, MissingRequiredProperty{Name: name}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (st *validationState) validateValuesStream(decoder *json.Decoder, schema Schema, instancePath, schemaPath []string, depth int) error {
+	tok, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		if schema.Nullable {
+			return nil
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		if err := skipValue(decoder, tok); err != nil {
+			return err
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	valuesSchemaPath := append(append([]string{}, schemaPath...), "values")
+
+	for decoder.More() {
+		keyTok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		name, _ := keyTok.(string)
+		subInstancePath := append(append([]string{}, instancePath...), name)
+		if err := st.validateStreamValue(decoder, *schema.Values, subInstancePath, valuesSchemaPath, depth); err != nil {
+			return err
+		}
+	}
+
+	_, err = decoder.Token() // closing '}'
+	return err
+}
+
+// validateDiscriminatorStream buffers properties seen before the
+// discriminator tag, since the schema to validate them against isn't known
+// until the tag is found. Once the tag is seen, the buffered properties are
+// validated and every subsequent property is validated directly off the
+// stream.
+func (st *validationState) validateDiscriminatorStream(decoder *json.Decoder, schema Schema, instancePath, schemaPath []string, depth int) error {
+	tok, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		if schema.Nullable {
+			return nil
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		if err := skipValue(decoder, tok); err != nil {
+			return err
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	tagSchemaPath := append(append([]string{}, schemaPath...), "discriminator", "tag")
+
+	type pendingProperty struct {
+		name string
+		raw  json.RawMessage
+	}
+
+	var pending []pendingProperty
+	var mapped *Schema
+	var mappingSchemaPath []string
+	seen := map[string]bool{}
+
+	for decoder.More() {
+		keyTok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		name, _ := keyTok.(string)
+
+		if mapped == nil && name == schema.Discriminator.Tag {
+			var tagValue interface{}
+			if err := decoder.Decode(&tagValue); err != nil {
+				return err
+			}
+
+			tagInstancePath := append(append([]string{}, instancePath...), name)
+
+			tag, isStr := tagValue.(string)
+			if !isStr {
+				if err := st.addErrorKind(tagInstancePath, tagSchemaPath, DiscriminatorTagNotString{}); err != nil {
+					return err
+				}
+
+				return drainObject(decoder)
+			}
+
+			sub, ok := schema.Discriminator.Mapping[tag]
+			if !ok {
+				unknownSchemaPath := append(append([]string{}, schemaPath...), "discriminator", "mapping")
+				if err := st.addErrorKind(tagInstancePath, unknownSchemaPath, DiscriminatorValueUnknown{Value: tag}); err != nil {
+					return err
+				}
+
+				return drainObject(decoder)
+			}
+
+			mapped = &sub
+			mappingSchemaPath = append(append([]string{}, schemaPath...), "discriminator", "mapping", tag)
+
+			for _, p := range pending {
+				seen[p.name] = true
+				buffered := json.NewDecoder(bytes.NewReader(p.raw))
+				if err := st.validateMappedProperty(buffered, *mapped, p.name, instancePath, mappingSchemaPath, depth); err != nil {
+					return err
+				}
+			}
+			pending = nil
+
+			continue
+		}
+
+		if mapped == nil {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err != nil {
+				return err
+			}
+
+			pending = append(pending, pendingProperty{name: name, raw: raw})
+			continue
+		}
+
+		seen[name] = true
+		if err := st.validateMappedProperty(decoder, *mapped, name, instancePath, mappingSchemaPath, depth); err != nil {
+			return err
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil { // closing '}'
+		return err
+	}
+
+	if mapped == nil {
+		return st.addErrorKind(instancePath, tagSchemaPath, DiscriminatorTagMissing{})
+	}
+
+	for name := range mapped.RequiredProperties {
+		if seen[name] {
+			continue
+		}
+
+		subSchemaPath := append(append([]string{}, mappingSchemaPath...), "properties", name)
+		if err := st.addErrorKind(instancePath, subSchemaPath, MissingRequiredProperty{Name: name}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateMappedProperty validates the next value on decoder as the
+// property named name of a FormProperties schema, consulting its
+// properties/optionalProperties/additionalProperties exactly as
+// validateProperties does for an in-memory instance.
+func (st *validationState) validateMappedProperty(decoder *json.Decoder, schema Schema, name string, instancePath, schemaPath []string, depth int) error {
+	subInstancePath := append(append([]string{}, instancePath...), name)
+
+	if sub, ok := schema.RequiredProperties[name]; ok {
+		subSchemaPath := append(append([]string{}, schemaPath...), "properties", name)
+		return st.validateStreamValue(decoder, sub, subInstancePath, subSchemaPath, depth)
+	}
+
+	if sub, ok := schema.OptionalProperties[name]; ok {
+		subSchemaPath := append(append([]string{}, schemaPath...), "optionalProperties", name)
+		return st.validateStreamValue(decoder, sub, subInstancePath, subSchemaPath, depth)
+	}
+
+	var discard interface{}
+	if err := decoder.Decode(&discard); err != nil {
+		return err
+	}
+
+	if schema.AdditionalProperties {
+		return nil
+	}
+
+	return st.addErrorKind(subInstancePath, schemaPath, AdditionalProperty{Name: name})
+}
+
+// skipValue discards the rest of the JSON value whose first token, first,
+// has already been read from decoder.
+func skipValue(decoder *json.Decoder, first json.Token) error {
+	delim, ok := first.(json.Delim)
+	if !ok || (delim != '{' && delim != '[') {
+		return nil
+	}
+
+	depth := 1
+	for depth > 0 {
+		tok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+
+	return nil
+}
+
+// drainObject discards the remainder of a JSON object whose opening '{' has
+// already been consumed from decoder.
+func drainObject(decoder *json.Decoder) error {
+	for decoder.More() {
+		if _, err := decoder.Token(); err != nil { // key
+			return err
+		}
+
+		var discard interface{}
+		if err := decoder.Decode(&discard); err != nil {
+			return err
+		}
+	}
+
+	_, err := decoder.Token() // closing '}'
+	return err
+}
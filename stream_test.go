@@ -0,0 +1,98 @@
+package jddf_test
+
+import (
+	"strings"
+	"testing"
+
+	jddf "github.com/jddf/jddf-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func collectStreamErrors(t *testing.T, validator jddf.Validator, schema jddf.Schema, json string) []jddf.ValidationError {
+	t.Helper()
+
+	var errs []jddf.ValidationError
+	err := validator.ValidateStream(schema, strings.NewReader(json), func(ve jddf.ValidationError) bool {
+		errs = append(errs, ve)
+		return true
+	})
+	assert.NoError(t, err)
+
+	return errs
+}
+
+func TestValidateStreamElements(t *testing.T) {
+	schema := jddf.Schema{Elements: &jddf.Schema{Type: jddf.TypeBoolean}}
+
+	assert.Empty(t, collectStreamErrors(t, jddf.Validator{}, schema, `[true, false, true]`))
+	assert.Equal(t, 2, len(collectStreamErrors(t, jddf.Validator{}, schema, `[true, 1, "no"]`)))
+}
+
+func TestValidateStreamProperties(t *testing.T) {
+	schema := jddf.Schema{
+		RequiredProperties: map[string]jddf.Schema{
+			"name": {Type: jddf.TypeString},
+		},
+		OptionalProperties: map[string]jddf.Schema{
+			"age": {Type: jddf.TypeUint8},
+		},
+	}
+
+	assert.Empty(t, collectStreamErrors(t, jddf.Validator{}, schema, `{"name":"Alice","age":30}`))
+
+	errs := collectStreamErrors(t, jddf.Validator{}, schema, `{"age":"not a number","extra":1}`)
+	assert.Equal(t, 3, len(errs)) // missing name, bad age, additional "extra"
+}
+
+func TestValidateStreamNullable(t *testing.T) {
+	schema := jddf.Schema{
+		Elements: &jddf.Schema{Type: jddf.TypeBoolean},
+		Nullable: true,
+	}
+
+	assert.Empty(t, collectStreamErrors(t, jddf.Validator{}, schema, `null`))
+	assert.Equal(t, 1, len(collectStreamErrors(t, jddf.Validator{}, jddf.Schema{Elements: schema.Elements}, `null`)))
+}
+
+func TestValidateStreamDiscriminatorPropertyBeforeTag(t *testing.T) {
+	schema := jddf.Schema{
+		Discriminator: jddf.Discriminator{
+			Tag: "type",
+			Mapping: map[string]jddf.Schema{
+				"a": {RequiredProperties: map[string]jddf.Schema{"value": {Type: jddf.TypeString}}},
+			},
+		},
+	}
+
+	assert.Empty(t, collectStreamErrors(t, jddf.Validator{}, schema, `{"value":"hi","type":"a"}`))
+
+	errs := collectStreamErrors(t, jddf.Validator{}, schema, `{"value":1,"type":"a"}`)
+	assert.Equal(t, 1, len(errs))
+}
+
+func TestValidateStreamMaxDepth(t *testing.T) {
+	schema := jddf.Schema{
+		Definitions: map[string]jddf.Schema{
+			"": {Ref: strptr("")},
+		},
+		Ref: strptr(""),
+	}
+
+	validator := jddf.Validator{MaxDepth: 3}
+	err := validator.ValidateStream(schema, strings.NewReader(`null`), func(jddf.ValidationError) bool {
+		return true
+	})
+	assert.Equal(t, jddf.ErrMaxDepthExceeded, err)
+}
+
+func TestValidateStreamCallbackStopsEarly(t *testing.T) {
+	schema := jddf.Schema{Elements: &jddf.Schema{Type: jddf.TypeBoolean}}
+
+	count := 0
+	err := jddf.Validator{}.ValidateStream(schema, strings.NewReader(`[1, 2, 3, 4]`), func(jddf.ValidationError) bool {
+		count++
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
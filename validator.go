@@ -0,0 +1,536 @@
+package jddf
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errMaxErrorsReached is an internal sentinel used to unwind validation
+// once Validator.MaxErrors has been reached. It is never returned to
+// callers of Validate.
+var errMaxErrorsReached = errors.New("jddf: max errors reached")
+
+// errStreamStopped is an internal sentinel used to unwind ValidateStream
+// once its callback has returned false. It is never returned to callers of
+// ValidateStream.
+var errStreamStopped = errors.New("jddf: stream validation stopped")
+
+// ValidationError describes a single way in which an instance failed to
+// satisfy a schema.
+//
+// InstancePath and SchemaPath are each a sequence of JSON Pointer reference
+// tokens (see RFC 6901), pointing respectively into the instance and into
+// the schema. Kind and Locale are excluded from the JSON representation, so
+// that marshaling a ValidationError continues to produce only the paths
+// expected by the JDDF spec test suite.
+type ValidationError struct {
+	InstancePath []string `json:"instancePath"`
+	SchemaPath   []string `json:"schemaPath"`
+
+	Kind   ErrorKind `json:"-"`
+	Locale Locale    `json:"-"`
+}
+
+// Error renders e using its Locale, or LocaleEN if none was set.
+func (e ValidationError) Error() string {
+	locale := e.Locale
+	if locale == nil {
+		locale = LocaleEN
+	}
+
+	return locale.Render(e.Kind)
+}
+
+// ValidationResult is the outcome of validating an instance against a
+// schema.
+type ValidationResult struct {
+	Errors []ValidationError
+}
+
+// Validator validates instances of data against JDDF schemas.
+//
+// The zero value of Validator is ready to use, and imposes no limits on
+// evaluation depth or the number of errors returned.
+type Validator struct {
+	// MaxDepth is the maximum number of "ref"s that may be followed before
+	// validation aborts with ErrMaxDepthExceeded. A value of zero means no
+	// limit is imposed.
+	//
+	// Whatever MaxDepth is, ValidateCompiled also aborts with
+	// ErrMaxDepthExceeded when a cross-document ref leads back to a ref that
+	// is already being followed for the same part of the instance.
+	MaxDepth int
+
+	// MaxErrors is the maximum number of ValidationErrors that Validate will
+	// return. Once this many errors have been found, validation stops early.
+	// A value of zero means no limit is imposed.
+	MaxErrors int
+
+	// Formats is consulted whenever a FormType schema with Type ==
+	// TypeString has a non-empty "format" keyword. A nil Formats disables
+	// format checking entirely, regardless of what schemas specify.
+	Formats *FormatRegistry
+
+	// Locale renders the ErrorKind of each ValidationError into the message
+	// returned by its Error method. A nil Locale defaults to LocaleEN.
+	Locale Locale
+}
+
+// Validate validates instance against schema, which must already have been
+// checked with Schema.Verify.
+func (v Validator) Validate(schema Schema, instance interface{}) (ValidationResult, error) {
+	locale := v.Locale
+	if locale == nil {
+		locale = LocaleEN
+	}
+
+	state := &validationState{
+		root:      schema,
+		maxDepth:  v.MaxDepth,
+		maxErrors: v.MaxErrors,
+		formats:   v.Formats,
+		locale:    locale,
+	}
+
+	err := state.validate(schema, instance, []string{}, []string{}, 0)
+	if err != nil && err != errMaxErrorsReached {
+		return ValidationResult{}, err
+	}
+
+	return ValidationResult{Errors: state.errors}, nil
+}
+
+// ValidateCompiled validates instance against the schema registered under id
+// in compiled, resolving any "id#name" refs against the rest of the bundle.
+//
+// It is the CompiledSchema counterpart to Validate, for schemas assembled
+// from more than one document with a Loader.
+func (v Validator) ValidateCompiled(compiled *CompiledSchema, id string, instance interface{}) (ValidationResult, error) {
+	root, ok := compiled.schemas[id]
+	if !ok {
+		return ValidationResult{}, ErrNoSuchSchemaID(id)
+	}
+
+	locale := v.Locale
+	if locale == nil {
+		locale = LocaleEN
+	}
+
+	state := &validationState{
+		root:      root,
+		maxDepth:  v.MaxDepth,
+		maxErrors: v.MaxErrors,
+		formats:   v.Formats,
+		locale:    locale,
+		docID:     id,
+		pool:      compiled.schemas,
+	}
+
+	err := state.validate(root, instance, []string{}, []string{}, 0)
+	if err != nil && err != errMaxErrorsReached {
+		return ValidationResult{}, err
+	}
+
+	return ValidationResult{Errors: state.errors}, nil
+}
+
+// splitRef splits a ref into the id of the document it points into and the
+// name of the definition within that document. A ref with no "#" is local
+// to currentDocID. A ref of the form "id#" (an empty name) refers to the
+// root of the schema registered under "id".
+func splitRef(ref, currentDocID string) (docID string, name string, hadHash bool) {
+	if idx := strings.IndexByte(ref, '#'); idx >= 0 {
+		id := ref[:idx]
+		if id == "" {
+			id = currentDocID
+		}
+
+		return id, ref[idx+1:], true
+	}
+
+	return currentDocID, ref, false
+}
+
+// validationState carries the state that accumulates over the course of a
+// single call to Validator.Validate.
+type validationState struct {
+	root      Schema
+	maxDepth  int
+	maxErrors int
+	formats   *FormatRegistry
+	locale    Locale
+	errors    []ValidationError
+
+	// docID, pool, and visiting support cross-document ref resolution via
+	// ValidateCompiled. For a plain Validate call, pool is nil and refs are
+	// always resolved against root.Definitions, exactly as before.
+	docID    string
+	pool     map[string]Schema
+	visiting map[string]bool
+
+	// sink, when set, receives each ValidationError in place of appending to
+	// errors, for ValidateStream. Returning false from sink aborts
+	// validation with errStreamStopped.
+	sink func(ValidationError) bool
+}
+
+// addError records a ValidationError with no specific ErrorKind. It exists
+// for structural mismatches (e.g. an object expected where an array was
+// found) that don't correspond to one of the named kinds.
+func (st *validationState) addError(instancePath, schemaPath []string) error {
+	return st.addErrorKind(instancePath, schemaPath, nil)
+}
+
+func (st *validationState) addErrorKind(instancePath, schemaPath []string, kind ErrorKind) error {
+	ve := ValidationError{
+		InstancePath: append([]string{}, instancePath...),
+		SchemaPath:   append([]string{}, schemaPath...),
+		Kind:         kind,
+		Locale:       st.locale,
+	}
+
+	if st.sink != nil {
+		if !st.sink(ve) {
+			return errStreamStopped
+		}
+
+		return nil
+	}
+
+	st.errors = append(st.errors, ve)
+
+	if st.maxErrors > 0 && len(st.errors) >= st.maxErrors {
+		return errMaxErrorsReached
+	}
+
+	return nil
+}
+
+func (st *validationState) validate(schema Schema, instance interface{}, instancePath, schemaPath []string, depth int) error {
+	form := schema.Form()
+
+	// A ref-form schema's own Nullable is checked before the ref is ever
+	// followed, so that {"ref":"x","nullable":true} accepts null without
+	// regard for what "x" says. Nullable on the referent still applies
+	// independently, once validate recurses into it below.
+	if instance == nil && schema.Nullable {
+		return nil
+	}
+
+	if form == FormRef {
+		if st.maxDepth > 0 && depth >= st.maxDepth {
+			return ErrMaxDepthExceeded
+		}
+
+		// Only a ValidateCompiled call (st.pool != nil) gives "#" its
+		// cross-document meaning; a plain Validate looks up *schema.Ref
+		// literally in root.Definitions, the same way Schema.Verify does.
+		name := *schema.Ref
+		var refDocID string
+		var hadHash, crossing bool
+
+		if st.pool != nil {
+			refDocID, name, hadHash = splitRef(*schema.Ref, st.docID)
+			crossing = refDocID != st.docID
+		}
+
+		savedRoot, savedDocID := st.root, st.docID
+		// Refs being followed all lie on one recursion path, along which the
+		// instance path only grows; so the same ref at the same instance path
+		// length means a cycle that consumes no instance, while a recursive
+		// schema descending into a finite instance is not mistaken for one.
+		visitKey := refDocID + "#" + name + "@" + strconv.Itoa(len(instancePath))
+
+		if crossing {
+			doc, ok := st.pool[refDocID]
+			if !ok {
+				return ErrNoSuchSchemaID(refDocID)
+			}
+
+			if st.visiting[visitKey] {
+				return ErrMaxDepthExceeded
+			}
+
+			if st.visiting == nil {
+				st.visiting = map[string]bool{}
+			}
+			st.visiting[visitKey] = true
+
+			st.root, st.docID = doc, refDocID
+		}
+
+		referent := st.root.Definitions[name]
+		if hadHash && name == "" {
+			referent = st.root
+		}
+
+		refSchemaPath := append(append([]string{}, "definitions"), name)
+		err := st.validate(referent, instance, instancePath, refSchemaPath, depth+1)
+
+		if crossing {
+			delete(st.visiting, visitKey)
+			st.root, st.docID = savedRoot, savedDocID
+		}
+
+		return err
+	}
+
+	if instance == nil {
+		if form == FormEmpty {
+			return nil
+		}
+
+		return st.addError(instancePath, schemaPath)
+	}
+
+	switch form {
+	case FormEmpty:
+		return nil
+	case FormType:
+		return st.validateType(schema, instance, instancePath, schemaPath)
+	case FormEnum:
+		return st.validateEnum(schema, instance, instancePath, schemaPath)
+	case FormElements:
+		return st.validateElements(schema, instance, instancePath, schemaPath, depth)
+	case FormProperties:
+		return st.validateProperties(schema, instance, instancePath, schemaPath, depth)
+	case FormValues:
+		return st.validateValues(schema, instance, instancePath, schemaPath, depth)
+	case FormDiscriminator:
+		return st.validateDiscriminator(schema, instance, instancePath, schemaPath, depth)
+	default:
+		return nil
+	}
+}
+
+func (st *validationState) validateType(schema Schema, instance interface{}, instancePath, schemaPath []string) error {
+	ok := false
+
+	switch schema.Type {
+	case TypeBoolean:
+		_, ok = instance.(bool)
+	case TypeString:
+		_, ok = instance.(string)
+	case TypeTimestamp:
+		if s, isStr := instance.(string); isStr {
+			_, err := time.Parse(time.RFC3339, s)
+			ok = err == nil
+		}
+	case TypeFloat32, TypeFloat64:
+		_, ok = instance.(float64)
+	case TypeInt8:
+		ok = isIntegerInRange(instance, -128, 127)
+	case TypeUint8:
+		ok = isIntegerInRange(instance, 0, 255)
+	case TypeInt16:
+		ok = isIntegerInRange(instance, -32768, 32767)
+	case TypeUint16:
+		ok = isIntegerInRange(instance, 0, 65535)
+	case TypeInt32:
+		ok = isIntegerInRange(instance, -2147483648, 2147483647)
+	case TypeUint32:
+		ok = isIntegerInRange(instance, 0, 4294967295)
+	}
+
+	if !ok {
+		return st.addErrorKind(instancePath, schemaPath, TypeMismatch{
+			Expected: schema.Type,
+			Actual:   jsonTypeName(instance),
+		})
+	}
+
+	if schema.Type == TypeString && schema.Format != "" && st.formats != nil {
+		checker, known := st.formats.Get(schema.Format)
+		if !known {
+			return ErrUnknownFormat(schema.Format)
+		}
+
+		if !checker.IsFormat(instance) {
+			formatSchemaPath := append(append([]string{}, schemaPath...), "format")
+			return st.addError(instancePath, formatSchemaPath)
+		}
+	}
+
+	return nil
+}
+
+// isIntegerInRange reports whether instance is a float64 holding an
+// integral value within [min, max].
+func isIntegerInRange(instance interface{}, min, max float64) bool {
+	f, ok := instance.(float64)
+	if !ok {
+		return false
+	}
+
+	return f == float64(int64(f)) && f >= min && f <= max
+}
+
+// jsonTypeName describes the JSON type of instance, for use in ErrorKinds.
+func jsonTypeName(instance interface{}) string {
+	switch instance.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", instance)
+	}
+}
+
+func (st *validationState) validateEnum(schema Schema, instance interface{}, instancePath, schemaPath []string) error {
+	if s, ok := instance.(string); ok {
+		for _, value := range schema.Enum {
+			if value == s {
+				return nil
+			}
+		}
+
+		return st.addErrorKind(instancePath, schemaPath, EnumMismatch{Allowed: schema.Enum, Actual: s})
+	}
+
+	return st.addErrorKind(instancePath, schemaPath, EnumMismatch{Allowed: schema.Enum, Actual: jsonTypeName(instance)})
+}
+
+func (st *validationState) validateElements(schema Schema, instance interface{}, instancePath, schemaPath []string, depth int) error {
+	arr, ok := instance.([]interface{})
+	if !ok {
+		return st.addError(instancePath, schemaPath)
+	}
+
+	elementsSchemaPath := append(append([]string{}, schemaPath...), "elements")
+	for i, elem := range arr {
+		elemInstancePath := append(append([]string{}, instancePath...), strconv.Itoa(i))
+		if err := st.validate(*schema.Elements, elem, elemInstancePath, elementsSchemaPath, depth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (st *validationState) validateProperties(schema Schema, instance interface{}, instancePath, schemaPath []string, depth int) error {
+	obj, ok := instance.(map[string]interface{})
+	if !ok {
+		return st.addError(instancePath, schemaPath)
+	}
+
+	for name, sub := range schema.RequiredProperties {
+		subSchemaPath := append(append([]string{}, schemaPath...), "properties", name)
+
+		value, present := obj[name]
+		if !present {
+			if err := st.addErrorKind(instancePath, subSchemaPath, MissingRequiredProperty{Name: name}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		subInstancePath := append(append([]string{}, instancePath...), name)
+		if err := st.validate(sub, value, subInstancePath, subSchemaPath, depth); err != nil {
+			return err
+		}
+	}
+
+	for name, sub := range schema.OptionalProperties {
+		value, present := obj[name]
+		if !present {
+			continue
+		}
+
+		subInstancePath := append(append([]string{}, instancePath...), name)
+		subSchemaPath := append(append([]string{}, schemaPath...), "optionalProperties", name)
+		if err := st.validate(sub, value, subInstancePath, subSchemaPath, depth); err != nil {
+			return err
+		}
+	}
+
+	if !schema.AdditionalProperties {
+		for name := range obj {
+			if _, ok := schema.RequiredProperties[name]; ok {
+				continue
+			}
+
+			if _, ok := schema.OptionalProperties[name]; ok {
+				continue
+			}
+
+			subInstancePath := append(append([]string{}, instancePath...), name)
+			if err := st.addErrorKind(subInstancePath, schemaPath, AdditionalProperty{Name: name}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (st *validationState) validateValues(schema Schema, instance interface{}, instancePath, schemaPath []string, depth int) error {
+	obj, ok := instance.(map[string]interface{})
+	if !ok {
+		return st.addError(instancePath, schemaPath)
+	}
+
+	valuesSchemaPath := append(append([]string{}, schemaPath...), "values")
+	for name, value := range obj {
+		subInstancePath := append(append([]string{}, instancePath...), name)
+		if err := st.validate(*schema.Values, value, subInstancePath, valuesSchemaPath, depth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (st *validationState) validateDiscriminator(schema Schema, instance interface{}, instancePath, schemaPath []string, depth int) error {
+	obj, ok := instance.(map[string]interface{})
+	if !ok {
+		return st.addError(instancePath, schemaPath)
+	}
+
+	tagSchemaPath := append(append([]string{}, schemaPath...), "discriminator", "tag")
+
+	tagValue, present := obj[schema.Discriminator.Tag]
+	if !present {
+		return st.addErrorKind(instancePath, tagSchemaPath, DiscriminatorTagMissing{})
+	}
+
+	tag, isStr := tagValue.(string)
+	if !isStr {
+		tagInstancePath := append(append([]string{}, instancePath...), schema.Discriminator.Tag)
+		return st.addErrorKind(tagInstancePath, tagSchemaPath, DiscriminatorTagNotString{})
+	}
+
+	mapped, ok := schema.Discriminator.Mapping[tag]
+	if !ok {
+		tagInstancePath := append(append([]string{}, instancePath...), schema.Discriminator.Tag)
+		mappingSchemaPath := append(append([]string{}, schemaPath...), "discriminator", "mapping")
+		return st.addErrorKind(tagInstancePath, mappingSchemaPath, DiscriminatorValueUnknown{Value: tag})
+	}
+
+	// The mapped schema's properties never include the discriminator tag
+	// itself (Schema.Verify guarantees this), so it must be excluded here to
+	// avoid it being rejected as an additional property.
+	filtered := make(map[string]interface{}, len(obj))
+	for name, value := range obj {
+		if name == schema.Discriminator.Tag {
+			continue
+		}
+
+		filtered[name] = value
+	}
+
+	mappingSchemaPath := append(append([]string{}, schemaPath...), "discriminator", "mapping", tag)
+	return st.validateProperties(mapped, filtered, instancePath, mappingSchemaPath, depth)
+}
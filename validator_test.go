@@ -102,6 +102,40 @@ func TestMaxErrors(t *testing.T) {
 	assert.Equal(t, 3, len(result.Errors))
 }
 
+func TestNullableRef(t *testing.T) {
+	validator := jddf.Validator{}
+	schema := jddf.Schema{
+		Definitions: map[string]jddf.Schema{
+			"x": {Type: jddf.TypeString},
+		},
+		Ref:      strptr("x"),
+		Nullable: true,
+	}
+
+	result, err := validator.Validate(schema, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Errors)
+
+	result, err = validator.Validate(schema, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Errors))
+}
+
+func TestValidateRefWithHashInDefinitionName(t *testing.T) {
+	validator := jddf.Validator{}
+	schema := jddf.Schema{
+		Definitions: map[string]jddf.Schema{
+			"a#b": {Type: jddf.TypeBoolean},
+		},
+		Ref: strptr("a#b"),
+	}
+	assert.NoError(t, schema.Verify())
+
+	result, err := validator.Validate(schema, 12345)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Errors))
+}
+
 func TestMaxDepth(t *testing.T) {
 	validator := jddf.Validator{MaxDepth: 3}
 	schema := jddf.Schema{